@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/usememos/memos/internal/logging"
+	"github.com/usememos/memos/internal/profile"
+	"github.com/usememos/memos/store"
+)
+
+// bootstrapStore builds the profile and store shared by every maintenance
+// subcommand (migrate, admin, export, import), without booting the HTTP server
+// those commands have no use for, and migrates the schema before handing
+// the store back so callers don't have to remember to run `memos migrate`
+// first. The root command's server path goes through the fx providers in
+// main.go instead, since it also needs fx.Lifecycle hooks for ordered
+// startup/shutdown.
+func bootstrapStore(ctx context.Context) (*profile.Profile, *store.Store, error) {
+	instanceProfile, err := newProfile()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build profile: %w", err)
+	}
+
+	dbDriver, err := newDBDriver(instanceProfile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create db driver: %w", err)
+	}
+
+	storeInstance := store.New(dbDriver, instanceProfile)
+	if err := storeInstance.Migrate(logging.IntoContext(ctx, currentLogger())); err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+
+	return instanceProfile, storeInstance, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/usememos/memos/store"
+)
+
+// adminCmd groups operator maintenance commands that act on a running
+// instance's data without going through the HTTP API.
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Administrative maintenance commands",
+}
+
+var adminResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password",
+	Short: "Reset a user's password",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		username, err := cmd.Flags().GetString("user")
+		if err != nil {
+			return err
+		}
+		if username == "" {
+			return fmt.Errorf("--user is required")
+		}
+		newPassword, err := cmd.Flags().GetString("password")
+		if err != nil {
+			return err
+		}
+
+		generated := false
+		if newPassword == "" {
+			newPassword, err = generateRandomPassword()
+			if err != nil {
+				return fmt.Errorf("failed to generate a random password: %w", err)
+			}
+			generated = true
+		}
+
+		ctx := context.Background()
+		_, storeInstance, err := bootstrapStore(ctx)
+		if err != nil {
+			return err
+		}
+
+		user, err := storeInstance.GetUser(ctx, &store.FindUser{Username: &username})
+		if err != nil {
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		if user == nil {
+			return fmt.Errorf("user %q not found", username)
+		}
+
+		passwordHashBytes, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		passwordHash := string(passwordHashBytes)
+
+		if _, err := storeInstance.UpdateUser(ctx, &store.UpdateUser{
+			ID:           user.ID,
+			PasswordHash: &passwordHash,
+		}); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+
+		if generated {
+			fmt.Printf("Password for %q has been reset to: %s\n", username, newPassword)
+		} else {
+			fmt.Printf("Password for %q has been reset\n", username)
+		}
+		return nil
+	},
+}
+
+// generateRandomPassword returns a random hex-encoded password used when
+// the operator doesn't supply one with --password.
+func generateRandomPassword() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func init() {
+	adminResetPasswordCmd.Flags().String("user", "", "username of the account to reset (required)")
+	adminResetPasswordCmd.Flags().String("password", "", "new password; a random one is generated and printed if omitted")
+
+	adminCmd.AddCommand(adminResetPasswordCmd)
+	rootCmd.AddCommand(adminCmd)
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestGenerateRandomPassword(t *testing.T) {
+	a, err := generateRandomPassword()
+	if err != nil {
+		t.Fatalf("generateRandomPassword returned error: %v", err)
+	}
+	if len(a) != 32 {
+		t.Fatalf("expected a 32-character hex password, got %d characters: %q", len(a), a)
+	}
+
+	b, err := generateRandomPassword()
+	if err != nil {
+		t.Fatalf("generateRandomPassword returned error: %v", err)
+	}
+	if a == b {
+		t.Fatalf("expected two calls to generate different passwords, both were %q", a)
+	}
+}
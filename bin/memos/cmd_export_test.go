@@ -0,0 +1,53 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestExportMemosToZip(t *testing.T) {
+	memos := []*store.Memo{
+		{UID: "one", Content: "hello"},
+		{UID: "two", Content: "world"},
+	}
+
+	var buf bytes.Buffer
+	n, err := exportMemosToZip(memos, &buf)
+	if err != nil {
+		t.Fatalf("exportMemosToZip returned error: %v", err)
+	}
+	if n != len(memos) {
+		t.Fatalf("expected %d entries written, got %d", len(memos), n)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read back archive: %v", err)
+	}
+	if len(zr.File) != len(memos) {
+		t.Fatalf("expected %d entries in archive, got %d", len(memos), len(zr.File))
+	}
+
+	for i, memo := range memos {
+		f := zr.File[i]
+		if want := memo.UID + ".md"; f.Name != want {
+			t.Fatalf("entry %d: expected name %q, got %q", i, want, f.Name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open entry %q: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read entry %q: %v", f.Name, err)
+		}
+		if string(content) != memo.Content {
+			t.Fatalf("entry %q: expected content %q, got %q", f.Name, memo.Content, string(content))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/usememos/memos/store"
+)
+
+// importCmd is the inverse of exportCmd: it creates one memo per *.md
+// entry in the given zip archive, owned by --creator.
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import memos from a zip archive produced by `memos export`",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		in, err := cmd.Flags().GetString("in")
+		if err != nil {
+			return err
+		}
+		if in == "" {
+			return fmt.Errorf("--in is required")
+		}
+		creatorID, err := cmd.Flags().GetInt32("creator")
+		if err != nil {
+			return err
+		}
+		if creatorID == 0 {
+			return fmt.Errorf("--creator is required")
+		}
+
+		ctx := context.Background()
+		_, storeInstance, err := bootstrapStore(ctx)
+		if err != nil {
+			return err
+		}
+
+		zr, err := zip.OpenReader(in)
+		if err != nil {
+			return fmt.Errorf("failed to open archive: %w", err)
+		}
+		defer zr.Close()
+
+		memoFiles, err := readMemoFiles(&zr.Reader)
+		if err != nil {
+			return err
+		}
+
+		for _, mf := range memoFiles {
+			if _, err := storeInstance.CreateMemo(ctx, &store.Memo{
+				CreatorID:  creatorID,
+				Content:    mf.Content,
+				Visibility: store.Private,
+			}); err != nil {
+				return fmt.Errorf("failed to create memo from %s: %w", mf.Name, err)
+			}
+		}
+
+		fmt.Printf("Imported %d memos from %s\n", len(memoFiles), in)
+		return nil
+	},
+}
+
+// memoFile is one "<uid>.md" entry read out of an export archive.
+type memoFile struct {
+	Name    string
+	Content string
+}
+
+// readMemoFiles reads every *.md entry out of zr, skipping directories and
+// any other file. Split out of importCmd's RunE so the archive-reading
+// logic can be unit tested without a real store.
+func readMemoFiles(zr *zip.Reader) ([]memoFile, error) {
+	var memoFiles []memoFile
+	for _, zf := range zr.File {
+		if zf.FileInfo().IsDir() || !strings.HasSuffix(zf.Name, ".md") {
+			continue
+		}
+
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s in archive: %w", zf.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive: %w", zf.Name, err)
+		}
+
+		memoFiles = append(memoFiles, memoFile{Name: zf.Name, Content: string(content)})
+	}
+	return memoFiles, nil
+}
+
+func init() {
+	importCmd.Flags().String("in", "", "path of the zip archive to read (required)")
+	importCmd.Flags().Int32("creator", 0, "ID of the user that imported memos should be created under (required)")
+	rootCmd.AddCommand(importCmd)
+}
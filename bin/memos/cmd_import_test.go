@@ -0,0 +1,59 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+func TestReadMemoFiles(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	entries := map[string]string{
+		"one.md": "hello",
+		"two.md": "world",
+	}
+	for name, content := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s to fixture archive: %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write %s to fixture archive: %v", name, err)
+		}
+	}
+	if _, err := zw.Create("notes/"); err != nil {
+		t.Fatalf("failed to add directory entry to fixture archive: %v", err)
+	}
+	if w, err := zw.Create("README.txt"); err != nil {
+		t.Fatalf("failed to add README.txt to fixture archive: %v", err)
+	} else if _, err := w.Write([]byte("not a memo")); err != nil {
+		t.Fatalf("failed to write README.txt to fixture archive: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("failed to finalize fixture archive: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to read back fixture archive: %v", err)
+	}
+
+	memoFiles, err := readMemoFiles(zr)
+	if err != nil {
+		t.Fatalf("readMemoFiles returned error: %v", err)
+	}
+	if len(memoFiles) != len(entries) {
+		t.Fatalf("expected %d memo files, got %d", len(entries), len(memoFiles))
+	}
+	for _, mf := range memoFiles {
+		want, ok := entries[mf.Name]
+		if !ok {
+			t.Fatalf("unexpected entry %q in result (README.txt/notes/ should have been skipped)", mf.Name)
+		}
+		if mf.Content != want {
+			t.Fatalf("entry %q: expected content %q, got %q", mf.Name, want, mf.Content)
+		}
+	}
+}
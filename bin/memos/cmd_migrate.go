@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd runs the store's pending schema migrations and exits, without
+// starting the HTTP server. It's meant for init containers and other
+// deployment setups that migrate the database as a separate step from
+// running the app. bootstrapStore already migrates as part of building the
+// store, so this command is just that plus a confirmation message.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Run pending database migrations and exit",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if _, _, err := bootstrapStore(context.Background()); err != nil {
+			return err
+		}
+
+		slog.Info("database migration completed")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -2,16 +2,24 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"path/filepath"
+	"sync"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/getsentry/sentry-go"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 
+	"github.com/usememos/memos/internal/config"
+	"github.com/usememos/memos/internal/logging"
 	"github.com/usememos/memos/internal/profile"
 	"github.com/usememos/memos/internal/version"
 	"github.com/usememos/memos/server"
@@ -29,127 +37,238 @@ const (
 ╚═╝     ╚═╝╚══════╝╚═╝     ╚═╝ ╚═════╝ ╚══════╝
 `
 )
+
 // Using viper and cobra a cli is created with viper handling the configuration and cobra being cli interface
 var (
+	// cfgFile holds the path passed via --config, if any.
+	cfgFile string
+
+	// loggerMu guards appLogger, the logger built by initLogging from the
+	// merged flag/env/config-file state. newStore and newServer attach it
+	// to the context.Context they pass to Store.Migrate/server.NewServer.
+	// The store and server packages do not read it back via
+	// logging.FromContext; their own slog calls go through the slog
+	// default, which initLogging also sets to this logger.
+	loggerMu  sync.RWMutex
+	appLogger = slog.Default()
+
 	// The cli is initialized here and passed to the main function
 	rootCmd = &cobra.Command{
 		Use:   "memos",
 		Short: `An open source, lightweight note-taking service. Easily capture and share your great thoughts.`,
 		Run: func(_ *cobra.Command, _ []string) {
-			instanceProfile := &profile.Profile{
-				Mode:        viper.GetString("mode"),
-				Addr:        viper.GetString("addr"),
-				Port:        viper.GetInt("port"),
-				UNIXSock:    viper.GetString("unix-sock"),
-				Data:        viper.GetString("data"),
-				Driver:      viper.GetString("driver"),
-				DSN:         viper.GetString("dsn"),
-				InstanceURL: viper.GetString("instance-url"),
-				Version:     version.GetCurrentVersion(viper.GetString("mode")),
-			}
-			// error handling for if the instance profile is invalid
-			if err := instanceProfile.Validate(); err != nil {
-				panic(err)
-			}
-			// error handling for if it failed to create a database driver
-			ctx, cancel := context.WithCancel(context.Background())
-			dbDriver, err := db.NewDBDriver(instanceProfile)
-			if err != nil {
-				cancel()
-				slog.Error("failed to create db driver", "error", err)
-				return
-			}
-			// error handling for if it failed to migrate
-			storeInstance := store.New(dbDriver, instanceProfile)
-			if err := storeInstance.Migrate(ctx); err != nil {
-				cancel()
-				slog.Error("failed to migrate", "error", err)
-				return
-			}
-			// error handling for if there was a problem initiating a server instance
-			s, err := server.NewServer(ctx, instanceProfile, storeInstance)
-			if err != nil {
-				cancel()
-				slog.Error("failed to create server", "error", err)
-				return
-			}
-
-			c := make(chan os.Signal, 1)
-			// Trigger graceful shutdown on SIGINT or SIGTERM.
-			// The default signal sent by the `kill` command is SIGTERM,
-			// which is taken as the graceful shutdown signal for many systems, eg., Kubernetes, Gunicorn.
-			signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-
-			if err := s.Start(ctx); err != nil {
-				if err != http.ErrServerClosed {
-					slog.Error("failed to start server", "error", err)
-					cancel()
-				}
-			}
+			app := fx.New(
+				fx.Provide(
+					newProfile,
+					newDBDriver,
+					newStore,
+					newServer,
+				),
+				// Force the graph to build a *server.Server, which pulls in
+				// everything it depends on.
+				fx.Invoke(func(*server.Server) {}),
+				// Route fx's own lifecycle/hook-error events through the
+				// configured logger instead of fx's default stdout writer, so
+				// e.g. a failed OnStart hook is still visible/forwarded to
+				// Sentry like every other error-level log line.
+				fx.WithLogger(func() fxevent.Logger {
+					return &fxevent.SlogLogger{Logger: currentLogger()}
+				}),
+			)
+			app.Run()
+		},
+	}
+)
 
-			printGreetings(instanceProfile)
+// newProfile builds the instance profile from viper and records the same
+// settings as config.Live. The profile is built once: a config file reload
+// updates config.Live (see applyRuntimeConfig) but not this profile, since
+// profile.Profile has no synchronization of its own and this exact pointer
+// is handed to the running server.Server. Its InstanceURL therefore keeps
+// the value it had at startup.
+func newProfile() (*profile.Profile, error) {
+	cfg := config.FromViper()
+	config.SetLive(cfg)
+	instanceProfile := &profile.Profile{
+		Mode:        cfg.Mode,
+		Addr:        cfg.Addr,
+		Port:        cfg.Port,
+		UNIXSock:    cfg.UNIXSock,
+		Data:        cfg.Data,
+		Driver:      cfg.Driver,
+		DSN:         cfg.DSN,
+		InstanceURL: cfg.InstanceURL,
+		Version:     version.GetCurrentVersion(cfg.Mode),
+	}
+	if err := instanceProfile.Validate(); err != nil {
+		return nil, err
+	}
 
-			go func() {
-				<-c
-				s.Shutdown(ctx)
-				cancel()
-			}()
+	return instanceProfile, nil
+}
+
+// newDBDriver constructs the database driver for the given profile.
+func newDBDriver(instanceProfile *profile.Profile) (db.Driver, error) {
+	return db.NewDBDriver(instanceProfile)
+}
 
-			// Wait for CTRL-C.
-			<-ctx.Done()
+// newStore constructs the store and schedules its migration to run as part
+// of the fx app's startup, before the server is allowed to accept traffic.
+// store.New itself takes no context, so the configured logger is instead
+// carried on the context handed to Migrate, which does.
+func newStore(lc fx.Lifecycle, dbDriver db.Driver, instanceProfile *profile.Profile) (*store.Store, error) {
+	storeInstance := store.New(dbDriver, instanceProfile)
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return storeInstance.Migrate(logging.IntoContext(ctx, currentLogger()))
 		},
+	})
+	return storeInstance, nil
+}
+
+// newServer constructs the HTTP server and wires its start/stop into the fx
+// lifecycle, so shutdown is ordered by fx instead of racing a goroutine
+// against the process exiting. As with newStore, the context handed to
+// server.NewServer carries the configured logger.
+//
+// s.Start blocks serving the listener, so it still has to run in its own
+// goroutine rather than inside OnStart itself; a failure there (bad
+// address, bad TLS cert, ...) is reported back to fx via shutdowner instead
+// of just being logged, so the app actually exits non-zero instead of
+// sitting idle with no server and no way out.
+func newServer(lc fx.Lifecycle, shutdowner fx.Shutdowner, instanceProfile *profile.Profile, storeInstance *store.Store) (*server.Server, error) {
+	ctx := logging.IntoContext(context.Background(), currentLogger())
+	s, err := server.NewServer(ctx, instanceProfile, storeInstance)
+	if err != nil {
+		return nil, err
 	}
-)
+
+	lc.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			go func() {
+				if err := s.Start(context.Background()); err != nil && err != http.ErrServerClosed {
+					slog.Error("failed to start server", "error", err)
+					if shutdownErr := shutdowner.Shutdown(fx.ExitCode(1)); shutdownErr != nil {
+						slog.Error("failed to shut down app after server start failure", "error", shutdownErr)
+					}
+				}
+			}()
+			printGreetings(instanceProfile)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Shutdown(ctx)
+		},
+	})
+
+	return s, nil
+}
 
 // This code sets up configuration defaults and flags for the cli
 func init() {
-	// setting default values
-	viper.SetDefault("mode", "dev")
-	viper.SetDefault("driver", "sqlite")
-	viper.SetDefault("port", 8081)
-
-	// setting command line flags
-	rootCmd.PersistentFlags().String("mode", "dev", `mode of server, can be "prod" or "dev" or "demo"`)
-	rootCmd.PersistentFlags().String("addr", "", "address of server")
-	rootCmd.PersistentFlags().Int("port", 8081, "port of server")
-	rootCmd.PersistentFlags().String("unix-sock", "", "path to the unix socket, overrides --addr and --port")
-	rootCmd.PersistentFlags().String("data", "", "data directory")
-	rootCmd.PersistentFlags().String("driver", "sqlite", "database driver")
-	rootCmd.PersistentFlags().String("dsn", "", "database source name(aka. DSN)")
-	rootCmd.PersistentFlags().String("instance-url", "", "the url of your memos instance")
-
-	if err := viper.BindPFlag("mode", rootCmd.PersistentFlags().Lookup("mode")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("addr", rootCmd.PersistentFlags().Lookup("addr")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("port", rootCmd.PersistentFlags().Lookup("port")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("unix-sock", rootCmd.PersistentFlags().Lookup("unix-sock")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("data", rootCmd.PersistentFlags().Lookup("data")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("driver", rootCmd.PersistentFlags().Lookup("driver")); err != nil {
+	// initConfig must run before initLogging: --log-level/--log-format/
+	// --sentry-dsn can themselves come from memos.yaml/.toml/.json, so the
+	// logger has to be built from viper's state *after* the config file has
+	// been merged into it, not before. The one unavoidable gap is initConfig's
+	// own "failed to read config file"/"using config file" log lines, which
+	// necessarily go through whatever logger was configured by the previous
+	// flags/env/config state (the slog default on first run).
+	cobra.OnInitialize(initConfig, initLogging)
+
+	// setting environment variables
+	viper.SetEnvPrefix("memos")
+	viper.AutomaticEnv()
+
+	// --config is a bootstrap-only flag (it names the file initConfig reads)
+	// and isn't itself one of the runtime settings in config.Configuration.
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default search paths: ./memos.yaml, $HOME/.memos/memos.yaml, /etc/memos/memos.yaml)")
+
+	// Every other flag, its default, and its MEMOS_ env binding are driven
+	// off internal/config's generated flag definitions instead of a
+	// hand-rolled PersistentFlags()/BindPFlag() pair per setting.
+	if err := config.RegisterFlags(rootCmd); err != nil {
 		panic(err)
 	}
-	if err := viper.BindPFlag("dsn", rootCmd.PersistentFlags().Lookup("dsn")); err != nil {
+}
+
+// initLogging sets the process-wide slog default logger from the resolved
+// --log-level/--log-format/--sentry-dsn settings. It runs ahead of every
+// command, including the migrate/admin/export/import subcommands.
+func initLogging() {
+	cfg := config.FromViper()
+	logger, err := logging.New(cfg.LogLevel, cfg.LogFormat, cfg.SentryDSN)
+	if err != nil {
 		panic(err)
 	}
-	if err := viper.BindPFlag("instance-url", rootCmd.PersistentFlags().Lookup("instance-url")); err != nil {
-		panic(err)
+
+	loggerMu.Lock()
+	appLogger = logger
+	loggerMu.Unlock()
+
+	// slog.SetDefault keeps main.go's own package-level slog calls (and
+	// anything else that hasn't been switched to reading the logger out of
+	// its context) on the configured handler; newStore/newServer inject
+	// currentLogger() into their context.Context explicitly instead of
+	// relying on this.
+	slog.SetDefault(logger)
+}
+
+// currentLogger returns the logger most recently built by initLogging.
+func currentLogger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return appLogger
+}
+
+// initConfig wires up an optional memos.yaml/.toml/.json config file on top
+// of the flags and env vars bound in init(), and watches it for changes so
+// that the fields covered by applyRuntimeConfig can be updated without a
+// restart.
+func initConfig() {
+	if cfgFile != "" {
+		viper.SetConfigFile(cfgFile)
+	} else {
+		viper.SetConfigName("memos")
+		viper.AddConfigPath(".")
+		if home, err := os.UserHomeDir(); err == nil {
+			viper.AddConfigPath(filepath.Join(home, ".memos"))
+		}
+		viper.AddConfigPath("/etc/memos")
 	}
 
-	// setting environment variables
-	viper.SetEnvPrefix("memos")
-	viper.AutomaticEnv()
-	if err := viper.BindEnv("instance-url", "MEMOS_INSTANCE_URL"); err != nil {
-		panic(err)
+	if err := viper.ReadInConfig(); err != nil {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			slog.Error("failed to read config file", "error", err)
+		}
+		return
 	}
+	slog.Info("using config file", "file", viper.ConfigFileUsed())
+
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		slog.Info("config file changed, reloading runtime settings", "file", e.Name)
+		initLogging()
+		applyRuntimeConfig()
+	})
+	viper.WatchConfig()
 }
+
+// applyRuntimeConfig re-reads the subset of settings that are safe to change
+// while the server is running and records them in config.Live, which is
+// already safe for concurrent access from this goroutine (the
+// viper.OnConfigChange callback) and whatever goroutines the server uses to
+// read it. This deliberately does NOT touch the profile built by newProfile:
+// profile.Profile is a plain struct with no synchronization of its own, and
+// it's the exact pointer fx handed to the running server.Server, so mutating
+// a field on it here would race with however that server reads it. A
+// reloaded InstanceURL or ReadOnly is therefore visible only through
+// config.Live(), not through the profile. Log level/format/Sentry DSN are covered by
+// initLogging, which OnConfigChange also calls. Fields such as the database
+// driver or listen address still require a restart.
+func applyRuntimeConfig() {
+	config.SetLive(config.FromViper())
+}
+
 // Prints the greeting banner and a message that is based on a given server profile
 func printGreetings(profile *profile.Profile) {
 	if profile.IsDev() {
@@ -188,6 +307,10 @@ See more in:
 
 // The main entry point for the go program
 func main() {
+	// Give the Sentry transport a chance to deliver any ERROR-level events
+	// logged just before exit; a no-op when --sentry-dsn wasn't set.
+	defer sentry.Flush(2 * time.Second)
+
 	if err := rootCmd.Execute(); err != nil {
 		panic(err)
 	}
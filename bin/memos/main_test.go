@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/usememos/memos/internal/config"
+)
+
+func TestApplyRuntimeConfigUpdatesInstanceURL(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	config.SetLive(&config.Configuration{InstanceURL: "https://old.example.com"})
+	viper.Set("instance-url", "https://new.example.com")
+
+	applyRuntimeConfig()
+
+	if live := config.Live(); live == nil || live.InstanceURL != "https://new.example.com" {
+		t.Fatalf("expected instance-url to be updated, got %+v", live)
+	}
+}
+
+func TestApplyRuntimeConfigUpdatesReadOnly(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("read-only", true)
+
+	applyRuntimeConfig()
+
+	if live := config.Live(); live == nil || !live.ReadOnly {
+		t.Fatalf("expected read-only feature toggle to be applied to config.Live, got %+v", live)
+	}
+}
+
+func TestConfigFileHotReload(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "memos.yaml")
+	if err := os.WriteFile(cfgPath, []byte("instance-url: https://first.example.com\n"), 0o600); err != nil {
+		t.Fatalf("failed to write temp config file: %v", err)
+	}
+
+	cfgFile = cfgPath
+	defer func() { cfgFile = "" }()
+
+	initConfig()
+
+	applyRuntimeConfig()
+	if live := config.Live(); live == nil || live.InstanceURL != "https://first.example.com" {
+		t.Fatalf("expected instance-url to be loaded from config file, got %+v", live)
+	}
+
+	if err := os.WriteFile(cfgPath, []byte("instance-url: https://second.example.com\n"), 0o600); err != nil {
+		t.Fatalf("failed to rewrite temp config file: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if live := config.Live(); live != nil && live.InstanceURL == "https://second.example.com" {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatalf("expected config.Live to pick up config change without a restart, got %+v", config.Live())
+}
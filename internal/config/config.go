@@ -0,0 +1,62 @@
+// Package config collects the operational settings that used to be
+// sprinkled through bin/memos/main.go as individual viper.GetString calls
+// into a single typed Configuration struct, along with the flag-name
+// constants and cobra/viper wiring needed to populate it.
+package config
+
+// Flag name constants. These double as the viper keys the corresponding
+// values are bound under, so a setting only has one name to get wrong.
+const (
+	FlagConfig        = "config"
+	FlagMode          = "mode"
+	FlagAddr          = "addr"
+	FlagPort          = "port"
+	FlagUnixSock      = "unix-sock"
+	FlagData          = "data"
+	FlagDriver        = "driver"
+	FlagDSN           = "dsn"
+	FlagInstanceURL   = "instance-url"
+	FlagLogLevel      = "log-level"
+	FlagLogFormat     = "log-format"
+	FlagSentryDSN     = "sentry-dsn"
+	FlagMetricsAddr   = "metrics-addr"
+	FlagTLSCert       = "tls-cert"
+	FlagTLSKey        = "tls-key"
+	FlagCORSOrigins   = "cors-origins"
+	FlagSessionSecret = "session-secret"
+	FlagReadOnly      = "read-only"
+)
+
+// Configuration mirrors internal/profile.Profile plus the settings that
+// don't belong on the hot runtime profile (TLS material, metrics, CORS,
+// session secrets, feature toggles). The GetX/SetX accessors in
+// config_gen.go are generated from this struct's fields by the tool in
+// internal/config/gen; RegisterFlags and FromViper in registry.go are
+// hand-written, since they also need the per-field metadata (default,
+// usage, env var) in flags.go that isn't derivable from the struct alone.
+// Add a field here, a Flag constant above, a flagDefinition in flags.go and
+// a line in FromViper, then re-run go generate.
+//
+//go:generate go run ./gen -type=Configuration -out=config_gen.go
+type Configuration struct {
+	Mode          string
+	Addr          string
+	Port          int
+	UNIXSock      string
+	Data          string
+	Driver        string
+	DSN           string
+	InstanceURL   string
+	LogLevel      string
+	LogFormat     string
+	SentryDSN     string
+	MetricsAddr   string
+	TLSCert       string
+	TLSKey        string
+	CORSOrigins   []string
+	SessionSecret string
+	// ReadOnly is a feature toggle exposed through Live. Safe to flip at
+	// runtime via a config file reload, unlike e.g. Driver. Nothing in
+	// memos checks it, so setting it does not reject writes.
+	ReadOnly bool
+}
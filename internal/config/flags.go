@@ -0,0 +1,35 @@
+package config
+
+// flagDefinition describes one Configuration field's cobra flag and its
+// viper/env-var bindings. RegisterFlags walks a slice of these instead of
+// main.go hand-rolling a PersistentFlags().String(...)/BindPFlag(...) pair
+// per setting.
+type flagDefinition struct {
+	Name    string
+	EnvVar  string
+	Usage   string
+	Default any
+}
+
+// flagDefinitions is the single source of truth for every Configuration
+// flag. Adding a field to Configuration means adding one entry here (plus
+// its Flag constant and its line in FromViper).
+var flagDefinitions = []flagDefinition{
+	{Name: FlagMode, EnvVar: "MEMOS_MODE", Usage: `mode of server, can be "prod" or "dev" or "demo"`, Default: "dev"},
+	{Name: FlagAddr, EnvVar: "MEMOS_ADDR", Usage: "address of server", Default: ""},
+	{Name: FlagPort, EnvVar: "MEMOS_PORT", Usage: "port of server", Default: 8081},
+	{Name: FlagUnixSock, EnvVar: "MEMOS_UNIX_SOCK", Usage: "path to the unix socket, overrides --addr and --port", Default: ""},
+	{Name: FlagData, EnvVar: "MEMOS_DATA", Usage: "data directory", Default: ""},
+	{Name: FlagDriver, EnvVar: "MEMOS_DRIVER", Usage: "database driver", Default: "sqlite"},
+	{Name: FlagDSN, EnvVar: "MEMOS_DSN", Usage: "database source name(aka. DSN)", Default: ""},
+	{Name: FlagInstanceURL, EnvVar: "MEMOS_INSTANCE_URL", Usage: "the url of your memos instance", Default: ""},
+	{Name: FlagLogLevel, EnvVar: "MEMOS_LOG_LEVEL", Usage: `log level, one of "debug", "info", "warn", "error"`, Default: "info"},
+	{Name: FlagLogFormat, EnvVar: "MEMOS_LOG_FORMAT", Usage: `log output format, one of "text" or "json"`, Default: "text"},
+	{Name: FlagSentryDSN, EnvVar: "MEMOS_SENTRY_DSN", Usage: "Sentry DSN to forward ERROR-level logs to, disabled if empty", Default: ""},
+	{Name: FlagMetricsAddr, EnvVar: "MEMOS_METRICS_ADDR", Usage: "address to serve Prometheus metrics on, disabled if empty", Default: ""},
+	{Name: FlagTLSCert, EnvVar: "MEMOS_TLS_CERT", Usage: "path to TLS certificate", Default: ""},
+	{Name: FlagTLSKey, EnvVar: "MEMOS_TLS_KEY", Usage: "path to TLS key", Default: ""},
+	{Name: FlagCORSOrigins, EnvVar: "MEMOS_CORS_ORIGINS", Usage: "comma-separated list of allowed CORS origins", Default: []string{}},
+	{Name: FlagSessionSecret, EnvVar: "MEMOS_SESSION_SECRET", Usage: "secret used to sign session cookies", Default: ""},
+	{Name: FlagReadOnly, EnvVar: "MEMOS_READ_ONLY", Usage: "read-only feature toggle exposed via config.Live, safe to flip at runtime via a config file reload; not enforced, writes are still accepted", Default: false},
+}
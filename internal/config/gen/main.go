@@ -0,0 +1,124 @@
+// Command gen emits the GetX/SetX accessor methods for a struct type's
+// fields. It backs the //go:generate directive in internal/config/config.go:
+// parse config.go in the working directory, find the struct named by -type,
+// and write one GetX/SetX pair per field to -out.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+var (
+	typeName = flag.String("type", "", "name of the struct to generate accessors for")
+	outFile  = flag.String("out", "", "file to write the generated accessors to")
+)
+
+type field struct {
+	Name string
+	Type string
+}
+
+const tmplText = `// Code generated by go generate; DO NOT EDIT.
+// Regenerate with: go generate ./internal/config/...
+
+package config
+{{range .Fields}}
+func (c *{{$.TypeName}}) Get{{.Name}}() {{.Type}}  { return c.{{.Name}} }
+func (c *{{$.TypeName}}) Set{{.Name}}(v {{.Type}}) { c.{{.Name}} = v }
+{{end}}
+`
+
+func main() {
+	flag.Parse()
+	if *typeName == "" || *outFile == "" {
+		log.Fatal("both -type and -out are required")
+	}
+
+	fields, err := structFields("config.go", *typeName)
+	if err != nil {
+		log.Fatalf("gen: %v", err)
+	}
+
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("accessors").Parse(tmplText))
+	if err := tmpl.Execute(&buf, struct {
+		TypeName string
+		Fields   []field
+	}{TypeName: *typeName, Fields: fields}); err != nil {
+		log.Fatalf("gen: rendering template: %v", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("gen: formatting output: %v", err)
+	}
+
+	if err := os.WriteFile(*outFile, formatted, 0o644); err != nil {
+		log.Fatalf("gen: writing %s: %v", *outFile, err)
+	}
+}
+
+// structFields parses srcFile (relative to the generator's own working
+// directory, i.e. internal/config when invoked via go:generate) and returns
+// the exported fields of the struct named typeName, in source order.
+func structFields(srcFile, typeName string) ([]field, error) {
+	fset := token.NewFileSet()
+	path := srcFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(".", path)
+	}
+	f, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var fields []field
+	ast.Inspect(f, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok || ts.Name.Name != typeName {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, sf := range st.Fields.List {
+			typ := exprString(sf.Type)
+			for _, name := range sf.Names {
+				if name.IsExported() {
+					fields = append(fields, field{Name: name.Name, Type: typ})
+				}
+			}
+		}
+		return false
+	})
+
+	if fields == nil {
+		return nil, fmt.Errorf("struct %s not found in %s", typeName, path)
+	}
+	return fields, nil
+}
+
+// exprString renders a field's type expression back to source text, e.g.
+// "string" or "[]string". Configuration only uses a handful of simple
+// scalar/slice types, so this doesn't need to handle the general case.
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
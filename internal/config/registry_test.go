@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestLiveConcurrentAccess exercises SetLive/Live from many goroutines at
+// once, the way a config-file hot-reload and a request handler would hit
+// them concurrently in a running server. It exists to be run with
+// `go test -race`: liveMu is what makes this safe, and a regression that
+// drops or narrows that locking should show up as a race here rather than
+// in production.
+func TestLiveConcurrentAccess(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetLive(&Configuration{InstanceURL: fmt.Sprintf("https://%d.example.com", i)})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Live()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,28 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// ctxKey is an unexported type so values stored by this package can't
+// collide with context keys set elsewhere.
+type ctxKey struct{}
+
+// IntoContext returns a copy of ctx carrying logger, so code that already
+// takes a context.Context can pick up the configured logger via
+// FromContext without a dedicated parameter. main.go attaches it to the
+// contexts it passes to server.NewServer and Store.Migrate; store.New
+// takes no context.
+func IntoContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger stored by IntoContext, or slog.Default()
+// if ctx doesn't carry one.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(ctxKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,75 @@
+// Package logging builds the process-wide *slog.Logger from the
+// configured level and format, optionally forwarding ERROR-level records
+// to Sentry.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// New builds a *slog.Logger writing to stderr at the given level and
+// format ("text" or "json"); an empty level means "info" and an empty
+// format means "text". If sentryDSN is non-empty, sentry-go is
+// initialized and ERROR-level records are additionally forwarded to
+// Sentry as events.
+func New(level, format, sentryDSN string) (*slog.Logger, error) {
+	lvl := slog.LevelInfo
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	var handler slog.Handler
+	switch format {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	case "text", "":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf(`invalid log format %q: must be "text" or "json"`, format)
+	}
+
+	if sentryDSN != "" {
+		if err := ensureSentryInitialized(sentryDSN); err != nil {
+			return nil, fmt.Errorf("failed to initialize sentry: %w", err)
+		}
+		handler = newSentryHandler(handler)
+	}
+
+	return slog.New(handler), nil
+}
+
+// sentryMu guards sentryDSNSet/sentryReady, which track the DSN sentry-go
+// was last initialized with. New is called again on every config-file
+// hot-reload (see main.go's OnConfigChange wiring), so without this guard a
+// reload would call sentry.Init on every reload even when --sentry-dsn
+// hadn't changed, leaking a new client/transport each time.
+var (
+	sentryMu     sync.Mutex
+	sentryDSNSet string
+	sentryReady  bool
+)
+
+// ensureSentryInitialized calls sentry.Init the first time it sees dsn, and
+// again only if a later call passes a different dsn.
+func ensureSentryInitialized(dsn string) error {
+	sentryMu.Lock()
+	defer sentryMu.Unlock()
+
+	if sentryReady && sentryDSNSet == dsn {
+		return nil
+	}
+	if err := sentry.Init(sentry.ClientOptions{Dsn: dsn}); err != nil {
+		return err
+	}
+	sentryDSNSet = dsn
+	sentryReady = true
+	return nil
+}
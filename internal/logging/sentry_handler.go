@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// sentryHandler wraps another slog.Handler, additionally forwarding
+// ERROR-level records to Sentry as events without changing how the
+// wrapped handler itself renders logs. It tracks attrs bound via
+// logger.With(...) itself, since record.Attrs in Handle only yields the
+// attrs passed directly to the log call.
+type sentryHandler struct {
+	slog.Handler
+	attrs []slog.Attr
+}
+
+func newSentryHandler(next slog.Handler) *sentryHandler {
+	return &sentryHandler{Handler: next}
+}
+
+func (h *sentryHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelError {
+		event := sentry.NewEvent()
+		event.Level = sentry.LevelError
+		event.Message = record.Message
+
+		extra := make(map[string]any, len(h.attrs)+record.NumAttrs())
+		collect := func(a slog.Attr) bool {
+			extra[a.Key] = a.Value.Any()
+			return true
+		}
+		for _, a := range h.attrs {
+			collect(a)
+		}
+		record.Attrs(collect)
+		if len(extra) > 0 {
+			event.Extra = extra
+		}
+
+		sentry.CaptureEvent(event)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *sentryHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	merged := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	merged = append(merged, h.attrs...)
+	merged = append(merged, attrs...)
+	return &sentryHandler{Handler: h.Handler.WithAttrs(attrs), attrs: merged}
+}
+
+func (h *sentryHandler) WithGroup(name string) slog.Handler {
+	return &sentryHandler{Handler: h.Handler.WithGroup(name), attrs: h.attrs}
+}